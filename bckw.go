@@ -0,0 +1,25 @@
+package ski
+
+func init() {
+	RegisterDialect("bckw", func(string) bool { return false }, parseBCKWOnly)
+}
+
+// parseBCKWOnly parses s with the same grammar as the built-in SKI
+// notation, except that S and I are rejected, for callers who want to
+// confirm an expression stays within the strict {B, C, K, W} basis (every
+// other combinator, including S and I, is derivable from these four).
+// Since "BCKW" is already a subset of the built-in SKI alphabet, this
+// dialect is never auto-selected by Parse; call it via
+// ParseDialect("bckw", s).
+func parseBCKWOnly(s string) (*Node, error) {
+	trimmed := stripWhitespace(s)
+	if trimmed == "" {
+		return nil, &ParseError{Input: s, Pos: Pos{Line: 1, Column: 1}, Message: "Invalid input"}
+	}
+	for i, r := range trimmed {
+		if r == 'S' || r == 'I' {
+			return nil, newParseError(trimmed, i, "S and I are not allowed in the BCKW-only dialect")
+		}
+	}
+	return parseSKI(trimmed)
+}
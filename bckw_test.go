@@ -0,0 +1,49 @@
+package ski
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseBCKWOnly(t *testing.T) {
+	n, err := ParseDialect("bckw", "BCKW")
+	if err != nil {
+		t.Fatalf(`ParseDialect("bckw", "BCKW"): %v`, err)
+	}
+	if got, want := n.String(), "BCKW"; got != want {
+		t.Errorf(`ParseDialect("bckw", "BCKW"): got %v, want %v`, got, want)
+	}
+}
+
+var invalidBCKW = []string{"S", "I", "BCSW", "BKIW", ""}
+
+func TestParseBCKWOnlyRejectsSAndI(t *testing.T) {
+	for _, s := range invalidBCKW {
+		if got, err := ParseDialect("bckw", s); err == nil {
+			t.Errorf(`ParseDialect("bckw", %v): got %#v, nil; want nil, error`, s, got)
+		}
+	}
+}
+
+func TestParseBCKWOnlyNotAutoSelected(t *testing.T) {
+	n, err := Parse("BKSW")
+	if err != nil {
+		t.Fatalf(`Parse("BKSW"): %v`, err)
+	}
+	if got, want := n.String(), "BKSW"; got != want {
+		t.Errorf(`Parse("BKSW"): got %v, want %v (bckw dialect must not shadow plain SKI)`, got, want)
+	}
+}
+
+func ExampleParseDialect_bckw() {
+	for _, s := range []string{"BCKW", "WBWB"} {
+		n, err := ParseDialect("bckw", s)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(s, n.String())
+	}
+	// Output:
+	// BCKW BCKW
+	// WBWB WBWB
+}
@@ -0,0 +1,91 @@
+package ski
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterDialect("blc", func(string) bool { return false }, parseBLC)
+}
+
+// parseBLC parses s as a closed term of John Tromp's Binary Lambda
+// Calculus: 00 introduces an abstraction, 01 applies its first following
+// term to its second, and a run of one or more 1s terminated by a 0 is a
+// de Bruijn index in unary (10 refers to the innermost enclosing
+// abstraction, 110 to the next one out, and so on). The term is compiled
+// to an equivalent *Node by the same bracket-abstraction subsystem
+// parseLambda uses. Since BLC's alphabet is a subset of Jot's, this
+// dialect is never auto-selected by Parse; call it via
+// ParseDialect("blc", s).
+func parseBLC(s string) (*Node, error) {
+	body := stripWhitespace(s)
+	if body == "" {
+		return nil, &ParseError{Input: s, Pos: Pos{Line: 1, Column: 1}, Message: "Invalid input"}
+	}
+	p := &blcParser{s: body}
+	t, err := p.parseTerm(nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(body) {
+		return nil, newParseError(body, p.pos, "Unexpected terms following "+body[:p.pos])
+	}
+	c := &compiler{free: make(map[string]Comb), algo: Algorithm}
+	return c.compile(t), nil
+}
+
+// A blcParser parses a whitespace-stripped BLC program by recursive
+// descent into a lambdaTerm, which is then handed to a compiler for
+// bracket abstraction exactly as if it had been parsed from \x.E source.
+type blcParser struct {
+	s   string
+	pos int
+}
+
+// parseTerm parses one BLC term. scopes holds the variable name bound by
+// each abstraction enclosing the term being parsed, outermost first, so a
+// de Bruijn index can be resolved to the lambdaVar it names.
+func (p *blcParser) parseTerm(scopes []string) (*lambdaTerm, error) {
+	switch rest := p.s[p.pos:]; {
+	case p.pos >= len(p.s):
+		return nil, newParseError(p.s, p.pos, "Unexpected end of input")
+	case strings.HasPrefix(rest, "00"):
+		p.pos += 2
+		name := fmt.Sprintf("x%d", len(scopes))
+		body, err := p.parseTerm(append(scopes, name))
+		if err != nil {
+			return nil, err
+		}
+		return &lambdaTerm{kind: lambdaAbs, x: name, body: body}, nil
+	case strings.HasPrefix(rest, "01"):
+		p.pos += 2
+		fn, err := p.parseTerm(scopes)
+		if err != nil {
+			return nil, err
+		}
+		arg, err := p.parseTerm(scopes)
+		if err != nil {
+			return nil, err
+		}
+		return mkApp(fn, arg), nil
+	case rest[0] == '1':
+		start := p.pos
+		var ones int
+		for p.pos < len(p.s) && p.s[p.pos] == '1' {
+			ones++
+			p.pos++
+		}
+		if p.pos >= len(p.s) || p.s[p.pos] != '0' {
+			return nil, newParseError(p.s, start, "Unterminated de Bruijn index")
+		}
+		p.pos++ // the terminating 0
+		idx := ones - 1
+		if idx >= len(scopes) {
+			return nil, newParseError(p.s, start, "De Bruijn index out of range")
+		}
+		return &lambdaTerm{kind: lambdaVar, v: scopes[len(scopes)-1-idx], pos: posAt(p.s, start)}, nil
+	default:
+		return nil, newParseError(p.s, p.pos, "Invalid BLC character")
+	}
+}
@@ -0,0 +1,73 @@
+package ski
+
+import (
+	"fmt"
+	"testing"
+)
+
+var validBLC = []struct {
+	s    string
+	want string
+}{
+	{"0010", "I"},    // λx.x
+	{"0000110", "K"}, // λx.λy.x
+	{"01" + "0010" + "0010", "II"},
+}
+
+func TestParseBLC(t *testing.T) {
+	for _, test := range validBLC {
+		n, err := ParseDialect("blc", test.s)
+		if err != nil {
+			t.Errorf(`ParseDialect("blc", %v): %v`, test.s, err)
+			continue
+		}
+		if got := n.String(); got != test.want {
+			t.Errorf(`ParseDialect("blc", %v): got %v, want %v`, test.s, got, test.want)
+		}
+	}
+}
+
+var invalidBLC = []string{
+	"",
+	"10",          // a variable with no enclosing abstraction
+	"0001",        // an application missing both of its operands
+	"0002",        // invalid character
+	"0010" + "10", // trailing garbage after a complete term
+}
+
+func TestParseInvalidBLC(t *testing.T) {
+	for _, s := range invalidBLC {
+		if got, err := ParseDialect("blc", s); err == nil {
+			t.Errorf(`ParseDialect("blc", %v): got %#v, nil; want nil, error`, s, got)
+		}
+	}
+}
+
+func TestParseBLCNotAutoSelected(t *testing.T) {
+	n, err := Parse("0010")
+	if err != nil {
+		t.Fatalf(`Parse("0010"): %v`, err)
+	}
+	// "0010" is valid Jot source too; Parse must keep treating it as Jot
+	// rather than silently switching to the blc dialect.
+	want, err := parseJot("0010")
+	if err != nil {
+		t.Fatalf("parseJot(0010): %v", err)
+	}
+	if got := n.String(); got != want.String() {
+		t.Errorf(`Parse("0010"): got %v, want %v (blc dialect must not shadow Jot)`, got, want.String())
+	}
+}
+
+func ExampleParseDialect_blc() {
+	for _, s := range []string{"0010", "0000110"} {
+		n, err := ParseDialect("blc", s)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(s, n.String())
+	}
+	// Output:
+	// 0010 I
+	// 0000110 K
+}
@@ -0,0 +1,176 @@
+// Command ski-repl is an interactive prompt for experimenting with the ski
+// package's SKI, Iota, Jot, and lambda-calculus notations.
+//
+// A line not starting with ':' is parsed as an expression, becomes the
+// current expression, and is simplified with the current strategy and step
+// limit. The following commands are also available:
+//
+//	:load FILE        read an expression from FILE and make it current
+//	:trace            toggle printing every intermediate Node while reducing
+//	:strategy NAME     set the reduction strategy (see ski.Strategy.String)
+//	:steps N          set the step limit (0 means unlimited)
+//	:reduce           apply the current expression to fresh arguments until
+//	                  it simplifies, like ski.Reduce
+//	:simplify         reduce the current expression with the current
+//	                  Evaluator settings
+//	:type EXPR        parse EXPR and print its structure without making it
+//	                  current
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jgarte/ski"
+)
+
+func main() {
+	r := &repl{out: os.Stdout}
+	r.run(os.Stdin)
+}
+
+type repl struct {
+	out     io.Writer
+	current *ski.Node
+	eval    ski.Evaluator
+	trace   bool
+}
+
+func (r *repl) run(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	fmt.Fprint(r.out, "ski> ")
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			r.eval1(line)
+		}
+		fmt.Fprint(r.out, "ski> ")
+	}
+}
+
+func (r *repl) eval1(line string) {
+	if strings.HasPrefix(line, ":") {
+		r.command(line[1:])
+		return
+	}
+	n, err := ski.Parse(line)
+	if err != nil {
+		fmt.Fprintln(r.out, err)
+		return
+	}
+	r.current = n
+	r.simplify()
+}
+
+func (r *repl) command(cmd string) {
+	name, arg, _ := strings.Cut(cmd, " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case "load":
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			fmt.Fprintln(r.out, err)
+			return
+		}
+		n, err := ski.Parse(string(data))
+		if err != nil {
+			fmt.Fprintln(r.out, err)
+			return
+		}
+		r.current = n
+		fmt.Fprintln(r.out, n.String())
+	case "trace":
+		r.trace = !r.trace
+		fmt.Fprintln(r.out, "trace:", r.trace)
+	case "strategy":
+		s, ok := parseStrategy(arg)
+		if !ok {
+			fmt.Fprintf(r.out, "unknown strategy %q\n", arg)
+			return
+		}
+		r.eval.Strategy = s
+		fmt.Fprintln(r.out, "strategy:", s)
+	case "steps":
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			fmt.Fprintln(r.out, err)
+			return
+		}
+		r.eval.MaxSteps = n
+		fmt.Fprintln(r.out, "steps:", n)
+	case "reduce":
+		r.reduce()
+	case "simplify":
+		r.simplify()
+	case "type":
+		n, err := ski.Parse(arg)
+		if err != nil {
+			fmt.Fprintln(r.out, err)
+			return
+		}
+		fmt.Fprintln(r.out, n.FullString())
+	default:
+		fmt.Fprintf(r.out, "unknown command %q\n", name)
+	}
+}
+
+func (r *repl) reduce() {
+	if r.current == nil {
+		fmt.Fprintln(r.out, "no current expression; parse one or use :load first")
+		return
+	}
+	n, args := ski.Reduce(r.current)
+	fmt.Fprintf(r.out, "%v (%v argument(s))\n", n.String(), args)
+}
+
+func (r *repl) simplify() {
+	if r.current == nil {
+		fmt.Fprintln(r.out, "no current expression; parse one or use :load first")
+		return
+	}
+	var trace chan *ski.Node
+	done := make(chan struct{})
+	if r.trace {
+		trace = make(chan *ski.Node)
+		r.eval.Trace = trace
+		go func() {
+			for n := range trace {
+				fmt.Fprintln(r.out, n.String())
+			}
+			close(done)
+		}()
+	} else {
+		r.eval.Trace = nil
+	}
+	n, steps, err := r.eval.Run(context.Background(), r.current)
+	if trace != nil {
+		close(trace)
+		<-done
+	}
+	r.current = n
+	if err != nil {
+		fmt.Fprintf(r.out, "%v after %v step(s): %v\n", n.String(), steps, err)
+		return
+	}
+	fmt.Fprintf(r.out, "%v (%v step(s))\n", n.String(), steps)
+}
+
+func parseStrategy(s string) (ski.Strategy, bool) {
+	for _, st := range []ski.Strategy{
+		ski.NormalOrder,
+		ski.ApplicativeOrder,
+		ski.LeftmostOutermost,
+		ski.OneStep,
+		ski.WeakHead,
+	} {
+		if st.String() == s {
+			return st, true
+		}
+	}
+	return 0, false
+}
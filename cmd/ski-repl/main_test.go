@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runREPL(t *testing.T, input string) string {
+	t.Helper()
+	var out bytes.Buffer
+	r := &repl{out: &out}
+	r.run(strings.NewReader(input))
+	return out.String()
+}
+
+func TestREPLLoadStepsStrategy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "expr.ski")
+	if err := os.WriteFile(path, []byte("SKSK"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	got := runREPL(t, ":load "+path+"\n:steps 1\n:strategy leftmost-outermost\n:simplify\n")
+	if !strings.Contains(got, "SKSK") {
+		t.Errorf("run(:load): output %q does not contain the loaded expression", got)
+	}
+	if !strings.Contains(got, "steps: 1") {
+		t.Errorf("run(:steps 1): output %q does not confirm the step limit", got)
+	}
+	if !strings.Contains(got, "strategy: leftmost-outermost") {
+		t.Errorf("run(:strategy leftmost-outermost): output %q does not confirm the strategy", got)
+	}
+	if !strings.Contains(got, "after 1 step(s): ski: step limit exceeded") {
+		t.Errorf("run(:simplify): output %q does not show the step limit being hit", got)
+	}
+}
+
+func TestREPLErrors(t *testing.T) {
+	got := runREPL(t, ":load "+filepath.Join(t.TempDir(), "missing.ski")+"\n")
+	if !strings.Contains(got, "no such file") {
+		t.Errorf("run(:load missing): output %q does not report the missing file", got)
+	}
+
+	got = runREPL(t, "$\n")
+	if !strings.Contains(got, "Invalid character") {
+		t.Errorf("run($): output %q does not report the parse error", got)
+	}
+
+	got = runREPL(t, ":bogus\n")
+	if !strings.Contains(got, `unknown command "bogus"`) {
+		t.Errorf("run(:bogus): output %q does not report the unknown command", got)
+	}
+}
@@ -0,0 +1,70 @@
+package ski
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// stripWhitespace returns s with every whitespace rune removed, for
+// dialects whose notation (unlike lambda-calculus's) treats whitespace as
+// insignificant, the same way Parse does for its built-in SKI, Iota, and
+// Jot notations.
+func stripWhitespace(s string) string {
+	return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), "")
+}
+
+// A Dialect is a pluggable front end for Parse: Detect reports whether a
+// trimmed input should be handled by this Dialect rather than Parse's
+// built-in SKI, Iota, Jot, and lambda-calculus notations, and ParseFn does
+// the actual parsing.
+type Dialect struct {
+	Name    string
+	Detect  func(string) bool
+	ParseFn func(string) (*Node, error)
+}
+
+// dialects holds every Dialect registered with RegisterDialect, in
+// registration order.
+var dialects []Dialect
+
+// RegisterDialect adds a new surface syntax to Parse. Whenever detect
+// reports true for a trimmed input, Parse calls parseFn instead of its
+// built-in lambda-calculus/SKI/Iota/Jot parsers. Dialects are tried in
+// registration order, before all of the built-in notations, so detect
+// should be narrow enough not to misfire on SKI, Iota, Jot, or another
+// registered dialect's input; name identifies the dialect for callers that
+// enumerate registered dialects and is not otherwise interpreted by Parse.
+//
+// A Dialect whose alphabet is a subset of an existing notation's, such as
+// Jot's or plain SKI's, cannot write a detect that is safe to run
+// automatically; such dialects register with a detect that always returns
+// false and are only reachable through ParseDialect.
+func RegisterDialect(name string, detect func(string) bool, parseFn func(string) (*Node, error)) {
+	dialects = append(dialects, Dialect{Name: name, Detect: detect, ParseFn: parseFn})
+}
+
+// Dialects returns the name of every Dialect registered with
+// RegisterDialect, in registration order.
+func Dialects() []string {
+	names := make([]string, len(dialects))
+	for i, d := range dialects {
+		names[i] = d.Name
+	}
+	return names
+}
+
+// ParseDialect parses s with the ParseFn of the Dialect registered under
+// name, bypassing that Dialect's Detect. This is the only way to reach a
+// Dialect, such as "bckw" or "blc", whose Detect always returns false
+// because its alphabet collides with a notation Parse already dispatches
+// to automatically. It returns an error if no Dialect is registered under
+// name.
+func ParseDialect(name, s string) (*Node, error) {
+	for _, d := range dialects {
+		if d.Name == name {
+			return d.ParseFn(s)
+		}
+	}
+	return nil, fmt.Errorf("ski: no dialect registered with name %q", name)
+}
@@ -0,0 +1,168 @@
+package ski
+
+import (
+	"context"
+	"errors"
+)
+
+// A Strategy selects how an Evaluator's Step reduces an expression.
+type Strategy int
+
+const (
+	// NormalOrder reduces the leftmost outermost redexes first, the way
+	// Simplify already does. It is the zero value and the default.
+	NormalOrder Strategy = iota
+	// ApplicativeOrder reduces the arguments of a redex before the redex
+	// itself.
+	ApplicativeOrder
+	// LeftmostOutermost reduces exactly one redex per Step, chosen by
+	// searching the tree leftmost-outermost first. It is a finer-grained
+	// alternative to NormalOrder, which may reduce several independent
+	// redexes in a single Step.
+	LeftmostOutermost
+	// OneStep reduces exactly one redex per Step, like LeftmostOutermost.
+	// It exists as its own Strategy so callers can single-step through a
+	// reduction regardless of which order produced the expression so far.
+	OneStep
+	// WeakHead reduces only redexes on the expression's leftmost spine,
+	// stopping once it is in weak head normal form without descending into
+	// arguments.
+	WeakHead
+)
+
+// String returns the name used to select a Strategy with Evaluator and the :strategy REPL command.
+func (s Strategy) String() string {
+	switch s {
+	case NormalOrder:
+		return "normal-order"
+	case ApplicativeOrder:
+		return "applicative-order"
+	case LeftmostOutermost:
+		return "leftmost-outermost"
+	case OneStep:
+		return "one-step"
+	case WeakHead:
+		return "weak-head"
+	default:
+		return "invalid"
+	}
+}
+
+// ErrStepLimit is returned by Evaluator.Run when a reduction does not reach
+// the end state its Strategy defines within MaxSteps steps.
+var ErrStepLimit = errors.New("ski: step limit exceeded")
+
+// An Evaluator performs step-bounded, cancellable, optionally traced
+// reductions of a Node, in place of calling Simplify or Reduce directly.
+// The zero value is a ready-to-use Evaluator with NormalOrder strategy and
+// no step limit.
+type Evaluator struct {
+	// Strategy selects how Run reduces an expression at each step.
+	Strategy Strategy
+	// MaxSteps bounds the number of steps Run will perform before
+	// returning ErrStepLimit. Zero means unlimited.
+	MaxSteps int
+	// Trace, if non-nil, receives every intermediate Node produced while
+	// Run is reducing an expression, including the initial Node and the
+	// final one. Run sends to Trace synchronously, so a caller that
+	// supplies an unbuffered channel must drain it concurrently.
+	Trace chan<- *Node
+}
+
+// Run reduces n by repeatedly applying e.Strategy until no further
+// reduction is possible, e.MaxSteps is exceeded, or ctx is done. It returns
+// the reduced Node, the number of steps taken, and a non-nil error if the
+// step budget was exceeded (ErrStepLimit) or ctx ended first (ctx.Err()).
+func (e *Evaluator) Run(ctx context.Context, n *Node) (*Node, int, error) {
+	steps := 0
+	for {
+		if e.Trace != nil {
+			e.Trace <- n
+		}
+		select {
+		case <-ctx.Done():
+			return n, steps, ctx.Err()
+		default:
+		}
+		m, ok := e.Step(n)
+		if !ok {
+			return n, steps, nil
+		}
+		if e.MaxSteps > 0 && steps >= e.MaxSteps {
+			return n, steps, ErrStepLimit
+		}
+		n = m
+		steps++
+	}
+}
+
+// Step performs a single reduction of n according to e.Strategy and reports
+// whether any reduction was made. What counts as "a single reduction"
+// depends on the Strategy: NormalOrder and ApplicativeOrder may each
+// contract several independent redexes in one Step, while
+// LeftmostOutermost, OneStep, and WeakHead each contract exactly one.
+func (e *Evaluator) Step(n *Node) (*Node, bool) {
+	switch e.Strategy {
+	case ApplicativeOrder:
+		return n.simplifyTreeApplicative()
+	case LeftmostOutermost, OneStep:
+		return n.reduceOne()
+	case WeakHead:
+		return n.weakHeadStep()
+	default:
+		return n.simplifyTree()
+	}
+}
+
+// simplifyTreeApplicative is simplifyTree with the traversal order reversed:
+// it simplifies a Node's subtrees before the Node itself, implementing
+// applicative-order (innermost-first) reduction. Like simplifyTree, it never
+// mutates an existing Node in place.
+func (n *Node) simplifyTreeApplicative() (*Node, bool) {
+	if (n.c == 0) == (n.l == nil) || (n.c == 0) == (n.r == nil) {
+		panic(n)
+	}
+	if n.c != 0 {
+		return n, false
+	}
+	l, lok := n.l.simplifyTreeApplicative()
+	r, rok := n.r.simplifyTreeApplicative()
+	if lok || rok {
+		n = Apply(l, r)
+	}
+	n, nok := n.simplifyNode()
+	return n, lok || rok || nok
+}
+
+// weakHeadStep contracts one redex on n's leftmost spine, normalizing that
+// spine before ever attempting to contract n itself, and never descends into
+// n.r. This reaches weak head normal form without reducing inside arguments
+// that a later redex may go on to discard.
+func (n *Node) weakHeadStep() (*Node, bool) {
+	if n.c != 0 {
+		return n, false
+	}
+	if l, ok := n.l.weakHeadStep(); ok {
+		return Apply(l, n.r), true
+	}
+	return n.simplifyNode()
+}
+
+// reduceOne contracts exactly one redex in n's subtree, preferring n itself
+// and otherwise searching left-to-right, and reports whether a redex was
+// found and contracted.
+func (n *Node) reduceOne() (*Node, bool) {
+	if n.c != 0 {
+		return n, false
+	}
+	if m, ok := n.simplifyNode(); ok {
+		return m, true
+	}
+	if l, ok := n.l.reduceOne(); ok {
+		return Apply(l, n.r), true
+	}
+	if r, ok := n.r.reduceOne(); ok {
+		return Apply(n.l, r), true
+	}
+	return n, false
+}
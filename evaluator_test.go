@@ -0,0 +1,138 @@
+package ski
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestEvaluatorRun(t *testing.T) {
+	n, err := Parse("SKSK")
+	if err != nil {
+		t.Fatalf("Parse(SKSK): %v", err)
+	}
+	var e Evaluator
+	got, _, err := e.Run(context.Background(), n)
+	if err != nil {
+		t.Fatalf("Run(SKSK): %v", err)
+	}
+	if got.String() != "K" {
+		t.Errorf("Run(SKSK): got %v, want K", got.String())
+	}
+}
+
+// omega returns SII(SII), a term with no normal form: simplifying it always
+// produces another instance of the same shape.
+func omega() *Node {
+	sii := Apply(Apply(NewNode(S), NewNode(I)), NewNode(I))
+	return Apply(sii, Apply(Apply(NewNode(S), NewNode(I)), NewNode(I)))
+}
+
+func TestEvaluatorStepLimit(t *testing.T) {
+	e := Evaluator{Strategy: ApplicativeOrder, MaxSteps: 20}
+	_, steps, err := e.Run(context.Background(), omega())
+	if !errors.Is(err, ErrStepLimit) {
+		t.Fatalf("Run(omega): got err %v, want ErrStepLimit", err)
+	}
+	if steps != 20 {
+		t.Errorf("Run(omega): got %v steps, want 20", steps)
+	}
+}
+
+// TestEvaluatorDiscardsUnusedArg checks that the K-rule discards an unused
+// argument structurally, without requiring it to be reduced first: K K omega
+// reaches normal form even though omega by itself never does.
+func TestEvaluatorDiscardsUnusedArg(t *testing.T) {
+	n := Apply(Apply(NewNode(K), NewNode(K)), omega())
+	var e Evaluator
+	got, _, err := e.Run(context.Background(), n)
+	if err != nil {
+		t.Fatalf("Run(K K omega): %v", err)
+	}
+	if got.String() != "K" {
+		t.Errorf("Run(K K omega): got %v, want K", got.String())
+	}
+}
+
+func TestEvaluatorCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e := Evaluator{Strategy: ApplicativeOrder}
+	_, steps, err := e.Run(ctx, omega())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run(omega) with canceled context: got err %v, want context.Canceled", err)
+	}
+	if steps != 0 {
+		t.Errorf("Run(omega) with canceled context: got %v steps, want 0", steps)
+	}
+}
+
+func TestEvaluatorTrace(t *testing.T) {
+	n, err := Parse("SKSK")
+	if err != nil {
+		t.Fatalf("Parse(SKSK): %v", err)
+	}
+	trace := make(chan *Node)
+	done := make(chan []string)
+	go func() {
+		var seen []string
+		for m := range trace {
+			seen = append(seen, m.String())
+		}
+		done <- seen
+	}()
+
+	e := Evaluator{Trace: trace}
+	got, _, err := e.Run(context.Background(), n)
+	close(trace)
+	seen := <-done
+	if err != nil {
+		t.Fatalf("Run(SKSK): %v", err)
+	}
+	if len(seen) < 2 {
+		t.Fatalf("Run(SKSK) trace: got %v entries, want at least 2: %v", len(seen), seen)
+	}
+	if seen[0] != "SKSK" {
+		t.Errorf("Run(SKSK) trace[0]: got %v, want SKSK", seen[0])
+	}
+	if last := seen[len(seen)-1]; last != got.String() {
+		t.Errorf("Run(SKSK) trace[last]: got %v, want %v", last, got.String())
+	}
+}
+
+func TestEvaluatorStrategiesAgreeOnNormalForm(t *testing.T) {
+	n, err := Parse("SKSK")
+	if err != nil {
+		t.Fatalf("Parse(SKSK): %v", err)
+	}
+	for _, s := range []Strategy{NormalOrder, LeftmostOutermost, OneStep, WeakHead} {
+		e := Evaluator{Strategy: s, MaxSteps: 1000}
+		got, _, err := e.Run(context.Background(), n)
+		if err != nil {
+			t.Errorf("Run(SKSK) under %v: %v", s, err)
+			continue
+		}
+		if got.String() != "K" {
+			t.Errorf("Run(SKSK) under %v: got %v, want K", s, got.String())
+		}
+	}
+}
+
+func TestStrategyString(t *testing.T) {
+	tests := []struct {
+		s    Strategy
+		want string
+	}{
+		{NormalOrder, "normal-order"},
+		{ApplicativeOrder, "applicative-order"},
+		{LeftmostOutermost, "leftmost-outermost"},
+		{OneStep, "one-step"},
+		{WeakHead, "weak-head"},
+		{Strategy(99), "invalid"},
+	}
+	for _, test := range tests {
+		if got := test.s.String(); got != test.want {
+			t.Errorf("Strategy(%v).String(): got %v, want %v", int(test.s), got, test.want)
+		}
+	}
+}
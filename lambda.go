@@ -0,0 +1,314 @@
+package ski
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AbstractionAlgorithm selects the bracket-abstraction strategy used to
+// compile lambda-calculus terms into combinators.
+type AbstractionAlgorithm int
+
+const (
+	// Curry performs classic bracket abstraction using only I, K, and S.
+	Curry AbstractionAlgorithm = iota
+	// Optimized additionally emits B, C, and W, producing smaller
+	// combinatory expressions than Curry.
+	Optimized
+)
+
+// Algorithm selects the AbstractionAlgorithm used by parseLambda.
+var Algorithm AbstractionAlgorithm = Optimized
+
+// lambdaKind identifies the shape of a lambdaTerm.
+type lambdaKind int
+
+const (
+	lambdaVar  lambdaKind = iota // a variable reference, named by v
+	lambdaAbs                    // an abstraction of x over body
+	lambdaApp                    // the application of fn to arg
+	lambdaComb                   // a combinator produced by bracket abstraction
+)
+
+// A lambdaTerm is an untyped lambda-calculus term, either as parsed from
+// source or, after bracket abstraction has eliminated every lambdaAbs,
+// as an equivalent combinatory term ready for conversion to a *Node.
+type lambdaTerm struct {
+	kind    lambdaKind
+	v       string // variable name, if kind == lambdaVar
+	x       string // bound variable name, if kind == lambdaAbs
+	body    *lambdaTerm
+	fn, arg *lambdaTerm
+	c       Comb // if kind == lambdaComb
+	pos     Pos  // source position, if kind == lambdaVar
+}
+
+func mkComb(c Comb) *lambdaTerm             { return &lambdaTerm{kind: lambdaComb, c: c} }
+func mkApp(fn, arg *lambdaTerm) *lambdaTerm { return &lambdaTerm{kind: lambdaApp, fn: fn, arg: arg} }
+
+// parseLambda returns the root Node of the combinatory expression equivalent
+// to the untyped lambda-calculus term represented by s, compiled via bracket
+// abstraction under Algorithm. Abstraction is written with a backslash or λ,
+// and application is left-associative juxtaposition:
+//
+//	\x.\y.x y
+//	(λx. x x)(λx. x x)
+//	\x y.x
+//
+// Identifiers that are never bound become free variables, represented the
+// same way Reduce represents its trailing arguments.
+func parseLambda(s string) (*Node, error) {
+	toks, err := lexLambda(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &lambdaParser{s: s, toks: toks}
+	t, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, newParseError(s, tok.off, fmt.Sprintf("Unexpected terms following %v", s[:tok.off]))
+	}
+	c := &compiler{free: make(map[string]Comb), algo: Algorithm}
+	return c.compile(t), nil
+}
+
+// A lambdaTokKind identifies the kind of a lambdaToken.
+type lambdaTokKind int
+
+const (
+	tokLambda lambdaTokKind = iota
+	tokDot
+	tokLParen
+	tokRParen
+	tokIdent
+	tokEOF
+)
+
+// A lambdaToken is a single lexical token of lambda-calculus source, and the
+// byte offset in the original input at which it begins.
+type lambdaToken struct {
+	kind lambdaTokKind
+	text string
+	off  int
+}
+
+// lexLambda tokenizes a lambda-calculus expression, skipping whitespace.
+func lexLambda(src string) ([]lambdaToken, error) {
+	var toks []lambdaToken
+	s := src
+	for len(s) > 0 {
+		off := len(src) - len(s)
+		r, size := utf8.DecodeRuneInString(s)
+		switch {
+		case unicode.IsSpace(r):
+		case r == '\\' || r == 'λ':
+			toks = append(toks, lambdaToken{tokLambda, string(r), off})
+		case r == '.':
+			toks = append(toks, lambdaToken{tokDot, ".", off})
+		case r == '(':
+			toks = append(toks, lambdaToken{tokLParen, "(", off})
+		case r == ')':
+			toks = append(toks, lambdaToken{tokRParen, ")", off})
+		case unicode.IsLetter(r) || r == '_':
+			i := size
+			for i < len(s) {
+				r2, size2 := utf8.DecodeRuneInString(s[i:])
+				if !unicode.IsLetter(r2) && !unicode.IsDigit(r2) && r2 != '_' {
+					break
+				}
+				i += size2
+			}
+			toks = append(toks, lambdaToken{tokIdent, s[:i], off})
+			s = s[i:]
+			continue
+		default:
+			return nil, newParseError(src, off, "Invalid character")
+		}
+		s = s[size:]
+	}
+	return append(toks, lambdaToken{tokEOF, "", len(src)}), nil
+}
+
+// A lambdaParser parses a token stream produced by lexLambda into a
+// lambdaTerm tree.
+type lambdaParser struct {
+	s    string // the original input, for error reporting
+	toks []lambdaToken
+	pos  int
+}
+
+func (p *lambdaParser) peek() lambdaToken { return p.toks[p.pos] }
+
+func (p *lambdaParser) next() lambdaToken {
+	t := p.toks[p.pos]
+	p.pos++
+	return t
+}
+
+func (p *lambdaParser) startsAtom() bool {
+	switch p.peek().kind {
+	case tokIdent, tokLParen, tokLambda:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseTerm parses one or more juxtaposed atoms, which associate to the left.
+func (p *lambdaParser) parseTerm() (*lambdaTerm, error) {
+	t, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	for p.startsAtom() {
+		arg, err := p.parseAtom()
+		if err != nil {
+			return nil, err
+		}
+		t = &lambdaTerm{kind: lambdaApp, fn: t, arg: arg}
+	}
+	return t, nil
+}
+
+// parseAtom parses a variable, a parenthesized term, or an abstraction.
+func (p *lambdaParser) parseAtom() (*lambdaTerm, error) {
+	switch tok := p.peek(); tok.kind {
+	case tokIdent:
+		p.next()
+		return &lambdaTerm{kind: lambdaVar, v: tok.text, pos: posAt(p.s, tok.off)}, nil
+	case tokLParen:
+		p.next()
+		t, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, newParseError(p.s, tok.off, "Mismatched parentheses")
+		}
+		p.next()
+		return t, nil
+	case tokLambda:
+		p.next()
+		var names []string
+		for p.peek().kind == tokIdent {
+			names = append(names, p.next().text)
+		}
+		if len(names) == 0 {
+			return nil, newParseError(p.s, tok.off, "Expected variable after "+tok.text)
+		}
+		if d := p.peek(); d.kind != tokDot {
+			return nil, newParseError(p.s, d.off, "Expected . after "+tok.text+strings.Join(names, " "))
+		}
+		p.next()
+		body, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		for i := len(names) - 1; i >= 0; i-- {
+			body = &lambdaTerm{kind: lambdaAbs, x: names[i], body: body}
+		}
+		return body, nil
+	default:
+		return nil, newParseError(p.s, tok.off, "Unexpected token")
+	}
+}
+
+// A compiler carries the state needed to turn a parsed lambdaTerm into a
+// *Node: the bracket-abstraction algorithm to use, and the assignment of
+// free variable names to the fresh negative Combs Reduce also uses for its
+// trailing arguments.
+type compiler struct {
+	free map[string]Comb
+	next Comb
+	algo AbstractionAlgorithm
+}
+
+// compile eliminates every abstraction in t via bracket abstraction and
+// converts the result to a *Node.
+func (c *compiler) compile(t *lambdaTerm) *Node {
+	return c.toNode(c.elim(t))
+}
+
+// elim returns a lambdaTerm equivalent to t containing no lambdaAbs nodes.
+func (c *compiler) elim(t *lambdaTerm) *lambdaTerm {
+	switch t.kind {
+	case lambdaVar, lambdaComb:
+		return t
+	case lambdaApp:
+		return mkApp(c.elim(t.fn), c.elim(t.arg))
+	case lambdaAbs:
+		return c.abstract(t.x, c.elim(t.body))
+	default:
+		panic("elim: invalid lambdaTerm")
+	}
+}
+
+// abstract implements bracket abstraction: it returns a term containing no
+// free occurrences of name, equivalent to an abstraction of name over t.
+// t must contain no lambdaAbs nodes.
+func (c *compiler) abstract(name string, t *lambdaTerm) *lambdaTerm {
+	if !occursFree(name, t) {
+		return mkApp(mkComb(K), t) // [x]E = KE, x∉E
+	}
+	if t.kind == lambdaVar {
+		return mkComb(I) // [x]x = I
+	}
+	l, r := t.fn, t.arg
+	if c.algo == Optimized {
+		if r.kind == lambdaVar && r.v == name {
+			if !occursFree(name, l) {
+				return l // [x](Ex) = E, x∉E (η)
+			}
+			return mkApp(mkComb(W), c.abstract(name, l)) // [x](Ex) = W[x]E
+		}
+		switch {
+		case !occursFree(name, l):
+			return mkApp(mkApp(mkComb(B), l), c.abstract(name, r)) // [x](EF) = BE([x]F), x∉E
+		case !occursFree(name, r):
+			return mkApp(mkApp(mkComb(C), c.abstract(name, l)), r) // [x](EF) = C([x]E)F, x∉F
+		}
+	}
+	return mkApp(mkApp(mkComb(S), c.abstract(name, l)), c.abstract(name, r)) // [x](EF) = S([x]E)([x]F)
+}
+
+// occursFree reports whether name occurs free in t.
+func occursFree(name string, t *lambdaTerm) bool {
+	switch t.kind {
+	case lambdaVar:
+		return t.v == name
+	case lambdaComb:
+		return false
+	case lambdaApp:
+		return occursFree(name, t.fn) || occursFree(name, t.arg)
+	default:
+		panic("occursFree: invalid lambdaTerm")
+	}
+}
+
+// toNode converts an abstraction-free lambdaTerm to a *Node, assigning each
+// distinct free variable name a fresh negative Comb.
+func (c *compiler) toNode(t *lambdaTerm) *Node {
+	switch t.kind {
+	case lambdaComb:
+		return newNode(t.c)
+	case lambdaVar:
+		comb, ok := c.free[t.v]
+		if !ok {
+			c.next--
+			comb = c.next
+			c.free[t.v] = comb
+		}
+		n := newNode(comb)
+		pos := t.pos
+		n.Pos = &pos
+		return n
+	case lambdaApp:
+		return Apply(c.toNode(t.fn), c.toNode(t.arg))
+	default:
+		panic("toNode: invalid lambdaTerm")
+	}
+}
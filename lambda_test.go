@@ -0,0 +1,91 @@
+package ski
+
+import (
+	"fmt"
+	"testing"
+)
+
+var validLambda = []struct {
+	s    string
+	want string
+}{
+	{`\x.x`, "I"},
+	{`λx.x`, "I"},
+	{`\x.\y.x`, "K"},
+	{`\x y.x`, "K"},
+	{`\x.y`, "Ka"},
+	{`\x.\y.\z.(x z)(y z)`, "S"},
+	{`(\x.x)(\y.y)`, "II"},
+}
+
+func TestParseLambdaOptimized(t *testing.T) {
+	for _, test := range validLambda {
+		n, err := Parse(test.s)
+		if err != nil {
+			t.Errorf("Parse(%v): %v", test.s, err)
+			continue
+		}
+		if got := n.String(); got != test.want {
+			t.Errorf("Parse(%v): got %v, want %v", test.s, got, test.want)
+		}
+	}
+}
+
+func TestParseLambdaCurry(t *testing.T) {
+	old := Algorithm
+	Algorithm = Curry
+	defer func() { Algorithm = old }()
+
+	n, err := Parse(`\x.\y.x`)
+	if err != nil {
+		t.Fatalf(`Parse(\x.\y.x): %v`, err)
+	}
+	if got, want := n.String(), "S(KK)I"; got != want {
+		t.Errorf(`Parse(\x.\y.x) under Curry: got %v, want %v`, got, want)
+	}
+}
+
+func TestParseLambdaSimplify(t *testing.T) {
+	n, err := Parse(`(\x.x)(\y.y)`)
+	if err != nil {
+		t.Fatalf(`Parse((\x.x)(\y.y)): %v`, err)
+	}
+	if got := Simplify(n).String(); got != "I" {
+		t.Errorf(`Simplify((\x.x)(\y.y)): got %v, want I`, got)
+	}
+}
+
+var invalidLambda = []string{
+	`\x`,
+	`\.x`,
+	`\x.`,
+	`(\x.x`,
+	`\x.x)`,
+	`\x.$`,
+}
+
+func TestParseInvalidLambda(t *testing.T) {
+	for _, s := range invalidLambda {
+		if got, err := Parse(s); err == nil {
+			t.Errorf("Parse(%v): got %#v, nil; want nil, error", s, got)
+		}
+	}
+}
+
+func ExampleParse_lambda() {
+	for _, s := range []string{
+		`\x.x`,
+		`\x.\y.x`,
+		`\x.\y.\z.(x z)(y z)`,
+	} {
+		n, err := Parse(s)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(s, n.String())
+	}
+	// Output:
+	// \x.x I
+	// \x.\y.x K
+	// \x.\y.\z.(x z)(y z) S
+}
@@ -0,0 +1,153 @@
+package ski
+
+import "sync"
+
+// A nodePair is the key under which Pool.Apply caches a compound Node.
+type nodePair struct{ l, r *Node }
+
+// A nodeStep records the memoized result of simplifying a Node, either one
+// step (simplifyNode) or all the way through its subtree (simplifyTree).
+type nodeStep struct {
+	n       *Node
+	changed bool
+}
+
+// A Pool hash-conses Nodes built through Apply, so that structurally
+// identical subtrees built through the same Pool share a single *Node:
+// a pointer comparison becomes a semantic equality check (see Equal), and
+// simplifyTree can memoize its work by Node identity instead of redoing it
+// every time a shared subtree is visited again, which is what the S and W
+// reduction rules do whenever they duplicate an argument. Since a Node's
+// fields never change after it is built, a memoized simplification stays
+// valid for the Node's entire lifetime, so treeMemo turns repeated
+// reductions of a shared subtree from a full re-walk into a single map
+// lookup. A Pool's methods are safe for concurrent use by multiple
+// goroutines, so independent subtrees built through the same Pool may be
+// reduced in parallel.
+type Pool struct {
+	mu       sync.Mutex
+	apps     map[nodePair]*Node
+	combs    map[Comb]*Node
+	memo     map[*Node]nodeStep
+	treeMemo map[*Node]nodeStep
+
+	// disableCache turns off both hash-consing and memoization, for
+	// benchmarking the win this Pool provides against the plain, unshared
+	// allocation Apply used before Pool existed.
+	disableCache bool
+}
+
+// NewPool returns an empty Pool.
+func NewPool() *Pool {
+	return &Pool{
+		apps:     make(map[nodePair]*Node),
+		combs:    make(map[Comb]*Node),
+		memo:     make(map[*Node]nodeStep),
+		treeMemo: make(map[*Node]nodeStep),
+	}
+}
+
+// Reset discards everything p has hash-consed and memoized so far. Nodes
+// already handed out by p remain valid, but future Apply/Comb calls will
+// build fresh Nodes instead of returning ones from before the Reset. Use
+// this to bound a long-lived Pool's memory.
+func (p *Pool) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.apps = make(map[nodePair]*Node)
+	p.combs = make(map[Comb]*Node)
+	p.memo = make(map[*Node]nodeStep)
+	p.treeMemo = make(map[*Node]nodeStep)
+}
+
+// Apply returns the application of m to n, reusing the Node p built for this
+// exact pair of Nodes before, if any.
+func (p *Pool) Apply(m, n *Node) *Node {
+	if p.disableCache {
+		return &Node{l: m, r: n}
+	}
+	key := nodePair{m, n}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if got, ok := p.apps[key]; ok {
+		return got
+	}
+	node := &Node{l: m, r: n}
+	p.apps[key] = node
+	return node
+}
+
+// Comb returns the Node representing c, reusing the Node p built for c
+// before, if any. Unlike newNode, a Node returned by Comb may be shared with
+// other callers, so its Pos field must not be set.
+func (p *Pool) Comb(c Comb) *Node {
+	if c < I || W < c {
+		panic("Pool.Comb: invalid Comb parameter")
+	}
+	if p.disableCache {
+		return newNode(c)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if got, ok := p.combs[c]; ok {
+		return got
+	}
+	node := newNode(c)
+	p.combs[c] = node
+	return node
+}
+
+// memoGet returns the memoized simplification of n, if p has one.
+func (p *Pool) memoGet(n *Node) (nodeStep, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.memo[n]
+	return s, ok
+}
+
+// memoSet records the simplification of n for future memoGet calls.
+func (p *Pool) memoSet(n *Node, s nodeStep) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.memo[n] = s
+}
+
+// treeMemoGet returns the memoized, fully-walked simplification of n's
+// subtree, if p has one.
+func (p *Pool) treeMemoGet(n *Node) (nodeStep, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.treeMemo[n]
+	return s, ok
+}
+
+// treeMemoSet records the fully-walked simplification of n's subtree for
+// future treeMemoGet calls.
+func (p *Pool) treeMemoSet(n *Node, s nodeStep) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.treeMemo[n] = s
+}
+
+// defaultPool backs the package-level Apply, and the memoization simplifyTree
+// uses; it is what lets the zero-value Node API benefit from hash-consing
+// without callers managing a Pool themselves.
+var defaultPool = NewPool()
+
+// Equal reports whether a and b represent the same combinatory expression,
+// ignoring any Pos they carry. Nodes built through the same Pool are often
+// the same pointer already whenever they are structurally equal, making this
+// cheap in practice, but Equal still falls back to a full recursive walk, so
+// it is correct for Nodes built without a Pool, or through different Pools.
+func Equal(a, b *Node) bool {
+	if a == b {
+		return true
+	}
+	if a.c != b.c {
+		return false
+	}
+	if a.c != 0 {
+		return true
+	}
+	return Equal(a.l, b.l) && Equal(a.r, b.r)
+}
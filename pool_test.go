@@ -0,0 +1,120 @@
+package ski
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPoolConcurrentApply(t *testing.T) {
+	p := NewPool()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Apply(p.Comb(S), p.Comb(K))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPoolReset(t *testing.T) {
+	p := NewPool()
+	before := p.Apply(p.Comb(S), p.Comb(K))
+	p.Reset()
+	after := p.Apply(p.Comb(S), p.Comb(K))
+	if before == after {
+		t.Errorf("Pool.Apply(S, K) after Reset: got the same Node %p as before Reset; want a fresh one", after)
+	}
+	if got := p.Apply(p.Comb(S), p.Comb(K)); got != after {
+		t.Errorf("Pool.Apply(S, K) called twice after Reset: got distinct Nodes %p, %p; want the same Node", after, got)
+	}
+}
+
+func TestPoolApplyShares(t *testing.T) {
+	p := NewPool()
+	a := p.Apply(p.Comb(S), p.Comb(K))
+	b := p.Apply(p.Comb(S), p.Comb(K))
+	if a != b {
+		t.Errorf("Pool.Apply(S, K) called twice: got distinct Nodes %p, %p; want the same Node", a, b)
+	}
+}
+
+func TestPoolCombPanicsOnInvalidComb(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Pool.Comb(0): got no panic, want one")
+		}
+	}()
+	NewPool().Comb(0)
+}
+
+func TestEqual(t *testing.T) {
+	a, err := Parse("S(KK)I")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	b, err := Parse("S(KK)I")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !Equal(a, b) {
+		t.Errorf("Equal(%v, %v): got false, want true", a, b)
+	}
+	c, err := Parse("S(KK)K")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if Equal(a, c) {
+		t.Errorf("Equal(%v, %v): got true, want false", a, c)
+	}
+}
+
+func TestEqualIgnoresPos(t *testing.T) {
+	a, _ := Parse("KI")
+	b, _ := Parse(" K I ")
+	if !Equal(a, b) {
+		t.Errorf("Equal(%v, %v): got false, want true (Pos should be ignored)", a, b)
+	}
+}
+
+// churchDouble builds W I applied depth times to a base Node I, using the W
+// rule (Wab = abb) to duplicate its argument at every level: simplifying it
+// fully takes exponential work in depth unless the duplicated subtree's
+// result is shared and memoized rather than recomputed on each occurrence.
+func churchDouble(depth int) *Node {
+	n := NewNode(I)
+	for i := 0; i < depth; i++ {
+		n = Apply(Apply(NewNode(W), NewNode(I)), n)
+	}
+	return n
+}
+
+func TestSimplifyChurchDoubleMatchesUnpooled(t *testing.T) {
+	pooled := Simplify(churchDouble(8)).String()
+	defaultPool.disableCache = true
+	unpooled := Simplify(churchDouble(8)).String()
+	defaultPool.disableCache = false
+	if pooled != unpooled {
+		t.Errorf("Simplify(churchDouble(8)): pooled %v, unpooled %v; want the same result", pooled, unpooled)
+	}
+}
+
+func benchmarkSimplifyChurchDouble(b *testing.B, depth int) {
+	for i := 0; i < b.N; i++ {
+		Simplify(churchDouble(depth))
+	}
+}
+
+// Depth 24 is deep enough to show the asymptotic gap the Pool is for: the
+// unpooled benchmark's work grows exponentially in depth, while the pooled
+// one, memoized by Node identity, grows close to linearly.
+func BenchmarkSimplifyChurchDoublePooled(b *testing.B) {
+	benchmarkSimplifyChurchDouble(b, 24)
+}
+
+func BenchmarkSimplifyChurchDoubleUnpooled(b *testing.B) {
+	defaultPool.disableCache = true
+	defer func() { defaultPool.disableCache = false }()
+	benchmarkSimplifyChurchDouble(b, 24)
+}
@@ -19,11 +19,84 @@ import (
 	"os"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Verbose causes successive simplification steps in Simplify to be printed to standard error.
 var Verbose bool
 
+// A Pos describes a location within a string parsed by Parse: a byte offset
+// and the 1-based line and column it corresponds to.
+type Pos struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// String returns a "line:column" representation of a Pos.
+func (p Pos) String() string { return fmt.Sprintf("%d:%d", p.Line, p.Column) }
+
+// posAt returns the Pos of the byte at offset off in s.
+func posAt(s string, off int) Pos {
+	line, col := 1, 1
+	for i, r := range s {
+		if i >= off {
+			break
+		}
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return Pos{Offset: off, Line: line, Column: col}
+}
+
+// A ParseError describes a single syntactic problem found by Parse or one of
+// the dialect parsers it dispatches to: where the problem is, the rune found
+// there (zero if the problem is at the end of the input), and a human-readable
+// description. This follows the "principled position information" carried
+// by every node and error in Go's cmd/compile/internal/syntax.
+type ParseError struct {
+	Input   string
+	Pos     Pos
+	Rune    rune
+	Message string
+}
+
+// Error returns a human-readable representation of a ParseError.
+func (e *ParseError) Error() string {
+	if e.Rune == 0 {
+		return fmt.Sprintf("%v: %v", e.Pos, e.Message)
+	}
+	return fmt.Sprintf("%v: %v %q", e.Pos, e.Message, e.Rune)
+}
+
+// newParseError returns a ParseError for the problem described by message,
+// located at byte offset off in s.
+func newParseError(s string, off int, message string) *ParseError {
+	var r rune
+	if off < len(s) {
+		r, _ = utf8.DecodeRuneInString(s[off:])
+	}
+	return &ParseError{Input: s, Pos: posAt(s, off), Rune: r, Message: message}
+}
+
+// A MultiError collects every ParseError found while parsing a single input,
+// so that a caller such as a REPL can report every mistake in one pass
+// instead of only the first.
+type MultiError []*ParseError
+
+// Error returns the Error of every ParseError in m, one per line.
+func (m MultiError) Error() string {
+	ss := make([]string, len(m))
+	for i, e := range m {
+		ss[i] = e.Error()
+	}
+	return strings.Join(ss, "\n")
+}
+
 // A Comb represents a combinator.
 type Comb int
 
@@ -50,15 +123,19 @@ func (c Comb) String() string {
 type Node struct {
 	l, r *Node
 	c    Comb
+
+	// Pos records where in a parsed input this Node originated, for Nodes
+	// returned by Parse. It is nil for Nodes built directly with NewNode,
+	// newNode, or Apply.
+	Pos *Pos
 }
 
-// NewNode returns a Node representing the specified Comb.
-// It panics if c does not represent a predeclared Comb value.
+// NewNode returns a Node representing the specified Comb, reusing the Node
+// defaultPool built for c before, if any. It panics if c does not represent
+// a predeclared Comb value. Since the Node it returns may be shared with
+// other callers, its Pos field must not be set; see Pool.Comb.
 func NewNode(c Comb) *Node {
-	if c < I || W < c {
-		panic("NewNode: invalid Comb parameter")
-	}
-	return newNode(c)
+	return defaultPool.Comb(c)
 }
 
 // newNode returns a Node representing the specified Comb.
@@ -80,11 +157,37 @@ func newNode(c Comb) *Node {
 //
 // In addition, Parse accepts strings representing valid Iota programs
 // composed of the characters * and i, as well as Jot programs.
+//
+// Finally, Parse accepts untyped lambda-calculus terms, with abstraction
+// written as \x.E or λx.E and application as left-associative juxtaposition;
+// these are compiled to the equivalent combinatory expression by bracket
+// abstraction. Unlike the other notations, whitespace inside a lambda term
+// is significant, since it separates identifiers.
+//
+// Additional surface syntaxes registered with RegisterDialect are tried
+// first, in registration order, so that a dialect whose own notation
+// happens to contain a backslash or λ (such as Unlambda's . primitive,
+// which takes the following character literally) is not mistaken for a
+// lambda-calculus term. They take priority over lambda-calculus, which in
+// turn takes priority over the built-in SKI, Iota, and Jot notations.
+//
+// On failure, Parse returns a *ParseError or, for input in the SKI, Iota, or
+// Jot notation, a MultiError holding every syntactic problem found, even if
+// there is only one.
 func Parse(s string) (*Node, error) {
-	s = strings.Join(strings.FieldsFunc(s, unicode.IsSpace), "")
-	if s == "" {
-		return nil, fmt.Errorf("Invalid input")
+	trimmed := strings.TrimFunc(s, unicode.IsSpace)
+	if trimmed == "" {
+		return nil, &ParseError{Input: s, Pos: Pos{Line: 1, Column: 1}, Message: "Invalid input"}
+	}
+	for _, d := range dialects {
+		if d.Detect(trimmed) {
+			return d.ParseFn(trimmed)
+		}
+	}
+	if strings.ContainsRune(trimmed, '\\') || strings.ContainsRune(trimmed, 'λ') {
+		return parseLambda(trimmed)
 	}
+	s = strings.Join(strings.FieldsFunc(s, unicode.IsSpace), "")
 	switch s[0] {
 	case '(', 'I', 'K', 'S', 'B', 'C', 'W', ')':
 		return parseSKI(s)
@@ -93,7 +196,7 @@ func Parse(s string) (*Node, error) {
 	case '0', '1':
 		return parseJot(s)
 	default:
-		return nil, fmt.Errorf("Invalid character %v", string(s[0]))
+		return nil, newParseError(s, 0, "Invalid character")
 	}
 }
 
@@ -115,12 +218,15 @@ func parseSKI(s string) (*Node, error) {
 	}
 	var openparen bool
 	stack := make([]*Node, 0)
-	for _, b := range s {
+	for i, b := range s {
 		switch b {
 		case '(':
 			openparen = true
 		case 'I', 'K', 'S', 'B', 'C', 'W':
-			stack = append(stack, NewNode(combs[b]))
+			node := newNode(combs[b])
+			pos := posAt(s, i)
+			node.Pos = &pos
+			stack = append(stack, node)
 			if openparen {
 				openparen = false
 				continue
@@ -139,10 +245,13 @@ func parseSKI(s string) (*Node, error) {
 	return stack[0], nil
 }
 
-// checkSKI checks that s is a valid SKI expression and returns an error otherwise.
+// checkSKI checks that s is a valid SKI expression and returns an error
+// otherwise, accumulating every problem found into a MultiError rather than
+// stopping at the first.
 func checkSKI(s string) error {
+	var errs MultiError
 	var op, cp int
-	for _, b := range s {
+	for i, b := range s {
 		switch b {
 		case 'I', 'K', 'S', 'B', 'C', 'W':
 		case '(':
@@ -150,18 +259,18 @@ func checkSKI(s string) error {
 		case ')':
 			cp++
 		default:
-			return fmt.Errorf("Invalid SKI character %v", string(b))
+			errs = append(errs, newParseError(s, i, "Invalid SKI character"))
 		}
 	}
 	if op != cp {
-		return fmt.Errorf("Mismatched parentheses in %v (%v vs. %v)", s, op, cp)
+		errs = append(errs, newParseError(s, 0, fmt.Sprintf("Mismatched parentheses in %v (%v vs. %v)", s, op, cp)))
 	}
 	for i, b := range s {
 		if b != '(' {
 			continue
 		}
 		j, depth := i+1, 1
-		for ; ; j++ {
+		for ; j < len(s); j++ {
 			switch s[j] {
 			case '(':
 				depth++
@@ -172,13 +281,19 @@ func checkSKI(s string) error {
 				break
 			}
 		}
+		if j >= len(s) {
+			continue // unbalanced; already reported above
+		}
 		switch n := countSubterms(s[i : j+1]); n {
 		case 0:
-			return fmt.Errorf("0 terms in %v", s[i:j+1])
+			errs = append(errs, newParseError(s, i, fmt.Sprintf("0 terms in %v", s[i:j+1])))
 		case 1:
-			return fmt.Errorf("1 term in %v", s[i:j+1])
+			errs = append(errs, newParseError(s, i, fmt.Sprintf("1 term in %v", s[i:j+1])))
 		}
 	}
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
@@ -230,7 +345,10 @@ func parseIota(s string) (*Node, error) {
 			}
 			stack = stack[:top]
 		case 'i':
-			stack = append(stack, newNode(ι))
+			node := newNode(ι)
+			pos := posAt(s, i)
+			node.Pos = &pos
+			stack = append(stack, node)
 		}
 	}
 	if len(stack) != 1 {
@@ -239,60 +357,88 @@ func parseIota(s string) (*Node, error) {
 	return stack[0], nil
 }
 
-// checkIota checks that s is a valid Iota program and returns an error otherwise.
-// An Iota expression is well-formed if and only if the last character is an i,
-// there are an equal number of *s and is to its left, and for every other character
-// in the expression, the number of *s to its left is at least equal to the number of is.
+// checkIota checks that s is a valid Iota program and returns an error
+// otherwise, accumulating every problem found into a MultiError rather than
+// stopping at the first. An Iota expression is well-formed if and only if
+// the last character is an i, there are an equal number of *s and is to its
+// left, and for every other character in the expression, the number of *s
+// to its left is at least equal to the number of is.
 func checkIota(s string) error {
+	var errs MultiError
 	var stars, is int
+	var complete bool
 	for i, b := range s {
 		switch b {
 		case '*':
 			stars++
 		case 'i':
 			is++
-			if is == stars+1 && i < len(s)-1 {
-				return fmt.Errorf("Unexpected terms following %v", s[:i+1])
+			if !complete && is == stars+1 && i < len(s)-1 {
+				errs = append(errs, newParseError(s, i+1, fmt.Sprintf("Unexpected terms following %v", s[:i+1])))
+				complete = true // the expression is already complete; counting further would misjudge it as incomplete
 			}
 		default:
-			return fmt.Errorf("Invalid Iota character %v", string(b))
+			errs = append(errs, newParseError(s, i, "Invalid Iota character"))
 		}
 	}
-	switch n := stars + 1 - is; {
-	case n == 1:
-		return fmt.Errorf("Incomplete expression (expected 1 more term)")
-	case n > 1:
-		return fmt.Errorf("Incomplete expression (expected %v more terms)", n)
-	case n < 0:
-		panic("unhandled case")
+	if !complete {
+		switch n := stars + 1 - is; {
+		case n == 1:
+			errs = append(errs, newParseError(s, len(s), "Incomplete expression (expected 1 more term)"))
+		case n > 1:
+			errs = append(errs, newParseError(s, len(s), fmt.Sprintf("Incomplete expression (expected %v more terms)", n)))
+		case n < 0:
+			panic("unhandled case")
+		}
+	}
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
 
-// parseJot returns the root Node of the combinatory expression represented by a Jot string.
-// The only valid characters are 0 and 1.
+// parseJot returns the root Node of the combinatory expression represented
+// by a Jot string. The only valid characters are 0 and 1. Every syntactic
+// problem found is accumulated into a MultiError rather than stopping at
+// the first.
 func parseJot(s string) (*Node, error) {
+	var errs MultiError
 	n := NewNode(I)
-	for _, b := range s {
+	for i, b := range s {
 		switch b {
 		case '0':
 			n = leftIota(n)
 		case '1':
 			n = rightIota(n)
 		default:
-			return nil, fmt.Errorf("Invalid Jot character %v", string(b))
+			errs = append(errs, newParseError(s, i, "Invalid Jot character"))
+			continue
 		}
+		pos := posAt(s, i)
+		n.Pos = &pos
+	}
+	if len(errs) > 0 {
+		return nil, errs
 	}
 	return n, nil
 }
 
 // simplifyNode makes any combinatorial simplifications applicable to a Node's subtree.
 // It returns the simplified subtree's root Node and a boolean value indicating
-// whether any simplifications were made.
-func (n *Node) simplifyNode() (*Node, bool) {
+// whether any simplifications were made. The result for a given Node is
+// memoized in defaultPool, since the S and W rules can make the same *Node
+// appear more than once in a tree being simplified.
+func (n *Node) simplifyNode() (result *Node, changed bool) {
 	if (n.c == 0) == (n.l == nil) || (n.c == 0) == (n.r == nil) {
 		panic(n)
 	}
+	orig := n
+	if !defaultPool.disableCache {
+		if s, ok := defaultPool.memoGet(orig); ok {
+			return s.n, s.changed
+		}
+		defer func() { defaultPool.memoSet(orig, nodeStep{result, changed}) }()
+	}
 	switch {
 	case n.l != nil && n.l.c != 0:
 		switch n.l.c {
@@ -328,21 +474,35 @@ func (n *Node) simplifyNode() (*Node, bool) {
 // simplifyTree traverses a Node's subtree and makes any combinatorial
 // simplifications applicable to the subtree of each Node it visits.
 // It returns the simplified subtree's root Node and a boolean value
-// indicating whether any simplifications were made.
-func (n *Node) simplifyTree() (*Node, bool) {
+// indicating whether any simplifications were made. It never mutates an
+// existing Node in place, rebuilding through Apply instead, so that Nodes
+// shared through a Pool stay valid for every Node that shares them. The
+// result for a given Node is memoized in defaultPool, since the S and W
+// rules can make the same *Node's subtree come up for a full walk more than
+// once in a tree being simplified.
+func (n *Node) simplifyTree() (result *Node, changed bool) {
 	if (n.c == 0) == (n.l == nil) || (n.c == 0) == (n.r == nil) {
 		panic(n)
 	}
 	if n.c != 0 {
 		return n, false
 	}
-	var lok, rok, nok bool
-	n, nok = n.simplifyNode()
+	orig := n
+	if !defaultPool.disableCache {
+		if s, ok := defaultPool.treeMemoGet(orig); ok {
+			return s.n, s.changed
+		}
+		defer func() { defaultPool.treeMemoSet(orig, nodeStep{result, changed}) }()
+	}
+	n, nok := n.simplifyNode()
 	if n.c != 0 {
 		return n, nok
 	}
-	n.l, lok = n.l.simplifyTree()
-	n.r, rok = n.r.simplifyTree()
+	l, lok := n.l.simplifyTree()
+	r, rok := n.r.simplifyTree()
+	if lok || rok {
+		n = Apply(l, r)
+	}
 	return n, lok || rok || nok
 }
 
@@ -386,8 +546,10 @@ func (n *Node) String() string {
 	return l + r
 }
 
-// Apply returns the application of m to n.
-func Apply(m, n *Node) *Node { return &Node{l: m, r: n} }
+// Apply returns the application of m to n. It delegates to a package-level
+// default Pool, so structurally identical applications built by Apply share
+// a single Node; use a Pool directly for an isolated cache.
+func Apply(m, n *Node) *Node { return defaultPool.Apply(m, n) }
 
 // leftApply returns the application of a Comb to a Node.
 func (n *Node) leftApply(c Comb) *Node { return Apply(newNode(c), n) }
@@ -13,6 +13,20 @@ var (
 	jotS  = Apply(Apply(Apply(Apply(Apply(Apply(Apply(newNode(S), Apply(newNode(K), Apply(newNode(S), Apply(newNode(K), Apply(newNode(S), Apply(newNode(K), Apply(newNode(S), Apply(newNode(K), Apply(newNode(S), Apply(newNode(K), newNode(I))))))))))), newNode(S)), newNode(K)), newNode(S)), newNode(K)), newNode(S)), newNode(K))
 )
 
+// stripPos returns a copy of n's subtree with every Pos field cleared, so
+// that tests can compare parser output structurally without needing to
+// predict the source positions Parse now records.
+func stripPos(n *Node) *Node {
+	if n == nil {
+		return nil
+	}
+	m := *n
+	m.Pos = nil
+	m.l = stripPos(n.l)
+	m.r = stripPos(n.r)
+	return &m
+}
+
 type skiTest struct {
 	fs       string
 	s        string
@@ -45,10 +59,10 @@ var validWithSpaces = []skiTest{
 
 func TestParseValidSKI(t *testing.T) {
 	for _, test := range append(valid, validWithSpaces...) {
-		if got, err := Parse(test.fs); err != nil || !reflect.DeepEqual(got, test.n) {
+		if got, err := Parse(test.fs); err != nil || !reflect.DeepEqual(stripPos(got), test.n) {
 			t.Errorf("parseSKI(%v): got %#v, %v; want %#v, nil", test.fs, got, err, test.n)
 		}
-		if got, err := Parse(test.s); err != nil || !reflect.DeepEqual(got, test.n) {
+		if got, err := Parse(test.s); err != nil || !reflect.DeepEqual(stripPos(got), test.n) {
 			t.Errorf("parseSKI(%v): got %#v, %v; want %#v, nil", test.s, got, err, test.n)
 		}
 	}
@@ -100,7 +114,7 @@ var invalidIota = []string{
 
 func TestParseValidIota(t *testing.T) {
 	for _, test := range validIota {
-		if got, err := Parse(test.s); err != nil || !reflect.DeepEqual(got, test.n) {
+		if got, err := Parse(test.s); err != nil || !reflect.DeepEqual(stripPos(got), test.n) {
 			t.Errorf("parseIota(%v): got %#v, %v; want %#v, nil", test.s, got, err, test.n)
 		}
 	}
@@ -125,7 +139,7 @@ var validJot = []struct {
 
 func TestParseJot(t *testing.T) {
 	for _, test := range validJot {
-		if got, err := Parse(test.s); err != nil || !reflect.DeepEqual(got, test.n) {
+		if got, err := Parse(test.s); err != nil || !reflect.DeepEqual(stripPos(got), test.n) {
 			t.Errorf("parseJot(%v): got %#v, %v; want %#v, nil", test.s, got, err, test.n)
 		}
 	}
@@ -199,6 +213,69 @@ func TestReduce(t *testing.T) {
 	}
 }
 
+func TestNodePos(t *testing.T) {
+	n, err := Parse("KI")
+	if err != nil {
+		t.Fatalf("Parse(KI): %v", err)
+	}
+	if n.Pos != nil {
+		t.Errorf("Parse(KI).Pos: got %v, want nil (only leaves carry a Pos)", n.Pos)
+	}
+	if got, want := *n.l.Pos, (Pos{Offset: 0, Line: 1, Column: 1}); got != want {
+		t.Errorf("Parse(KI).l.Pos: got %v, want %v", got, want)
+	}
+	if got, want := *n.r.Pos, (Pos{Offset: 1, Line: 1, Column: 2}); got != want {
+		t.Errorf("Parse(KI).r.Pos: got %v, want %v", got, want)
+	}
+}
+
+func TestMultiError(t *testing.T) {
+	_, err := Parse("KZY")
+	me, ok := err.(MultiError)
+	if !ok {
+		t.Fatalf("Parse(KZY): got error of type %T, want MultiError", err)
+	}
+	if len(me) != 2 {
+		t.Fatalf("Parse(KZY): got %v errors, want 2: %v", len(me), me)
+	}
+	for _, e := range me {
+		if e.Message != "Invalid SKI character" {
+			t.Errorf("Parse(KZY): got message %q, want %q", e.Message, "Invalid SKI character")
+		}
+	}
+	if me[0].Rune != 'Z' || me[1].Rune != 'Y' {
+		t.Errorf("Parse(KZY): got runes %q, %q; want 'Z', 'Y'", me[0].Rune, me[1].Rune)
+	}
+}
+
+func TestMultiErrorIotaAfterComplete(t *testing.T) {
+	_, err := Parse("*ii$")
+	me, ok := err.(MultiError)
+	if !ok {
+		t.Fatalf("Parse(*ii$): got error of type %T, want MultiError", err)
+	}
+	if len(me) != 2 {
+		t.Fatalf("Parse(*ii$): got %v errors, want 2: %v", len(me), me)
+	}
+	if me[0].Message != "Unexpected terms following *ii" {
+		t.Errorf("Parse(*ii$): got message %q, want %q", me[0].Message, "Unexpected terms following *ii")
+	}
+	if me[1].Message != "Invalid Iota character" || me[1].Rune != '$' {
+		t.Errorf("Parse(*ii$): got message %q, rune %q; want %q, '$'", me[1].Message, me[1].Rune, "Invalid Iota character")
+	}
+}
+
+func TestParseErrorPos(t *testing.T) {
+	_, err := Parse("")
+	pe, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("Parse(\"\"): got error of type %T, want *ParseError", err)
+	}
+	if got, want := pe.Pos.String(), "1:1"; got != want {
+		t.Errorf("Parse(\"\").Pos: got %v, want %v", got, want)
+	}
+}
+
 func ExampleReduce() {
 	for _, c := range []Comb{I, K, S} {
 		r, n := Reduce(NewNode(c))
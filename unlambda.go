@@ -0,0 +1,103 @@
+package ski
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+func init() {
+	RegisterDialect("unlambda", detectUnlambda, parseUnlambda)
+}
+
+// detectUnlambda reports whether s looks like Unlambda source. Unlambda's
+// prefix-application operator, a backtick, does not appear in any of Parse's
+// other notations, so its presence unambiguously selects this dialect.
+func detectUnlambda(s string) bool {
+	return strings.ContainsRune(s, '`')
+}
+
+// parseUnlambda parses s as an Unlambda program: prefix application is
+// written `FX, and s, k, and i are the S, K, and I combinators. The I/O
+// primitives . and r are not executable here, so each becomes its own fresh
+// free variable, the same way parseLambda represents an unbound identifier.
+// As with the SKI notation, whitespace is insignificant: it is removed before
+// parsing, including between . and the character it prints, so . never takes
+// a whitespace character as that character.
+func parseUnlambda(s string) (*Node, error) {
+	body := stripWhitespace(s)
+	p := &unlambdaParser{s: body, free: make(map[string]Comb)}
+	n, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(body) {
+		return nil, newParseError(body, p.pos, "Unexpected terms following "+body[:p.pos])
+	}
+	return n, nil
+}
+
+// An unlambdaParser parses a whitespace-stripped Unlambda program by
+// recursive descent; its prefix notation needs no lookahead or precedence
+// handling.
+type unlambdaParser struct {
+	s    string
+	pos  int
+	free map[string]Comb
+	next Comb
+}
+
+func (p *unlambdaParser) parseTerm() (*Node, error) {
+	if p.pos >= len(p.s) {
+		return nil, newParseError(p.s, p.pos, "Unexpected end of input")
+	}
+	r, size := utf8.DecodeRuneInString(p.s[p.pos:])
+	switch r {
+	case '`':
+		p.pos += size
+		fn, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		arg, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		return Apply(fn, arg), nil
+	case 's':
+		p.pos += size
+		return NewNode(S), nil
+	case 'k':
+		p.pos += size
+		return NewNode(K), nil
+	case 'i':
+		p.pos += size
+		return NewNode(I), nil
+	case 'r':
+		p.pos += size
+		return p.freeVar("r"), nil
+	case '.':
+		start := p.pos
+		p.pos += size
+		if p.pos >= len(p.s) {
+			return nil, newParseError(p.s, start, "Expected a character after .")
+		}
+		_, csize := utf8.DecodeRuneInString(p.s[p.pos:])
+		name := p.s[start : p.pos+csize]
+		p.pos += csize
+		return p.freeVar(name), nil
+	default:
+		return nil, newParseError(p.s, p.pos, "Invalid Unlambda character")
+	}
+}
+
+// freeVar returns the Node representing the free variable named name,
+// assigning it a fresh negative Comb the first time it is seen.
+func (p *unlambdaParser) freeVar(name string) *Node {
+	comb, ok := p.free[name]
+	if !ok {
+		p.next--
+		comb = p.next
+		p.free[name] = comb
+	}
+	return newNode(comb)
+}
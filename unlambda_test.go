@@ -0,0 +1,76 @@
+package ski
+
+import (
+	"fmt"
+	"testing"
+)
+
+var validUnlambda = []struct {
+	s    string
+	want string
+}{
+	{"`ki", "KI"},
+	{"```skki", "SKKI"},
+	{"``ki.a", "KIa"},
+	// .c takes the following character literally, even a backslash or λ
+	// that would otherwise look like the start of a lambda-calculus term.
+	{"`.\\i", "aI"},
+	{"`.λi", "aI"},
+}
+
+func TestParseUnlambda(t *testing.T) {
+	for _, test := range validUnlambda {
+		n, err := Parse(test.s)
+		if err != nil {
+			t.Errorf("Parse(%v): %v", test.s, err)
+			continue
+		}
+		if got := n.String(); got != test.want {
+			t.Errorf("Parse(%v): got %v, want %v", test.s, got, test.want)
+		}
+	}
+}
+
+func TestParseUnlambdaSimplify(t *testing.T) {
+	n, err := Parse("```skki")
+	if err != nil {
+		t.Fatalf("Parse(```skki): %v", err)
+	}
+	if got := Simplify(n).String(); got != "I" {
+		t.Errorf("Simplify(```skki): got %v, want I", got)
+	}
+}
+
+var invalidUnlambda = []string{
+	"`k",
+	"``ki`",
+	"`ki`ki",
+	"`.",
+	"`k$",
+}
+
+func TestParseInvalidUnlambda(t *testing.T) {
+	for _, s := range invalidUnlambda {
+		if got, err := Parse(s); err == nil {
+			t.Errorf("Parse(%v): got %#v, nil; want nil, error", s, got)
+		}
+	}
+}
+
+func ExampleParse_unlambda() {
+	for _, s := range []string{
+		"`ki",
+		"```skki",
+		"``ki.a",
+	} {
+		n, err := Parse(s)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(s, n.String())
+	}
+	// Output:
+	// `ki KI
+	// ```skki SKKI
+	// ``ki.a KIa
+}